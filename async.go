@@ -0,0 +1,52 @@
+package health
+
+import "time"
+
+// cachedResult is the latest outcome of a check, either run in the
+// background (Async) or reused within WithCacheDuration's TTL.
+type cachedResult struct {
+	at       time.Time
+	err      error
+	duration time.Duration
+}
+
+// startAsync runs c on a ticker until h is stopped, storing each result in
+// the cache for the probe handlers to serve without blocking.
+func (h *Health) startAsync(c Config) {
+	interval := c.Interval
+	if interval <= 0 {
+		// Matches Config.Interval's documented default of 10 * Timeout. By
+		// the time startAsync runs, Register has already defaulted
+		// c.Timeout to defaultCheckTimeout if it was left unset.
+		interval = c.Timeout * 10
+	}
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-h.ctx.Done():
+				return
+			case <-ticker.C:
+				h.runAndCache(c)
+			}
+		}
+	}()
+}
+
+// runAndCache runs c once and stores its result in the cache, used both for
+// the initial synchronous warm-up on Register and every subsequent tick.
+func (h *Health) runAndCache(c Config) {
+	err, elapsed := h.runCheck(h.ctx, c)
+
+	h.cacheMu.Lock()
+	h.cache[c.Name] = cachedResult{at: time.Now(), err: err, duration: elapsed}
+	h.cacheMu.Unlock()
+
+	h.notifyTransition(c.Name, err)
+}
@@ -0,0 +1,55 @@
+// Package grpc provides a health.CheckFunc that probes a downstream gRPC
+// service via the standard grpc.health.v1.Health/Check RPC.
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Config is the gRPC checker configuration settings container.
+type Config struct {
+	// Target is the dial target of the downstream gRPC service, e.g.
+	// "downstream:50051".
+	Target string
+	// Service is the service name to check, matching the "service" field of
+	// grpc.health.v1.HealthCheckRequest. Empty checks the server's overall status.
+	Service string
+	// DialOptions are passed through to grpc.DialContext. Defaults to an
+	// insecure connection.
+	DialOptions []grpc.DialOption
+}
+
+// New creates a check function that dials Target and calls
+// grpc.health.v1.Health/Check for Service.
+func New(config Config) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		opts := config.DialOptions
+		if len(opts) == 0 {
+			opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		}
+
+		conn, err := grpc.DialContext(ctx, config.Target, append(opts, grpc.WithBlock())...)
+		if err != nil {
+			return fmt.Errorf("could not dial %q: %w", config.Target, err)
+		}
+		defer conn.Close()
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+			Service: config.Service,
+		})
+		if err != nil {
+			return fmt.Errorf("health check rpc to %q failed: %w", config.Target, err)
+		}
+
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%q reported status %s", config.Target, resp.Status)
+		}
+
+		return nil
+	}
+}
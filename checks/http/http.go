@@ -0,0 +1,46 @@
+// Package http provides a health.CheckFunc that verifies an HTTP endpoint
+// responds with a successful status code.
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Config is the HTTP checker configuration settings container.
+type Config struct {
+	// URL is the endpoint that will be called to check the health.
+	URL string
+	// Client is the HTTP client used to perform the request. Defaults to
+	// http.DefaultClient when left nil.
+	Client *http.Client
+}
+
+// New creates a check function to ping a given HTTP endpoint and verify
+// that it returns a non-error status code.
+func New(config Config) func(ctx context.Context) error {
+	client := config.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.URL, nil)
+		if err != nil {
+			return fmt.Errorf("could not build request for %q: %w", config.URL, err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("could not reach %q: %w", config.URL, err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("%q returned status %d", config.URL, resp.StatusCode)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+// Package memcached provides a health.CheckFunc that verifies connectivity
+// to a Memcached instance.
+package memcached
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// sentinelKey is requested on every check. A cache miss is not a failure,
+// it only proves the server answered.
+const sentinelKey = "healthcheck"
+
+// Config is the Memcached checker configuration settings container.
+type Config struct {
+	// Addrs is the list of memcached server addresses, e.g. "localhost:11211".
+	Addrs []string
+	// Timeout overrides the client's per-operation socket timeout. Defaults
+	// to memcache.DefaultTimeout when zero.
+	Timeout time.Duration
+}
+
+// New builds a *memcache.Client held for the life of the checker (it pools
+// and reuses its own connections internally) and returns a check function
+// that issues a no-op Get against a sentinel key to verify the memcached
+// servers in Addrs are reachable. memcache.Client exposes no Close method,
+// so unlike checks/redis there is nothing to release on shutdown.
+func New(config Config) func(ctx context.Context) error {
+	client := memcache.New(config.Addrs...)
+	if config.Timeout > 0 {
+		client.Timeout = config.Timeout
+	}
+
+	return func(ctx context.Context) error {
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := client.Get(sentinelKey)
+			if err == memcache.ErrCacheMiss {
+				err = nil
+			}
+			errCh <- err
+		}()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errCh:
+			if err != nil {
+				return fmt.Errorf("could not reach memcached: %w", err)
+			}
+			return nil
+		}
+	}
+}
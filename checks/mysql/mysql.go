@@ -0,0 +1,31 @@
+// Package mysql provides a health.CheckFunc that verifies connectivity
+// to a MySQL instance.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Config is the MySQL checker configuration settings container.
+type Config struct {
+	// DSN is the MySQL connection string, e.g.
+	// "user:pass@tcp(host:3306)/db?charset=utf8".
+	DSN string
+}
+
+// New creates a check function to ping a given MySQL instance.
+func New(config Config) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		db, err := sql.Open("mysql", config.DSN)
+		if err != nil {
+			return fmt.Errorf("could not open mysql connection: %w", err)
+		}
+		defer db.Close()
+
+		return db.PingContext(ctx)
+	}
+}
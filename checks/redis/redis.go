@@ -0,0 +1,47 @@
+// Package redis provides a health.CheckFunc that PINGs a Redis instance.
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Config is the Redis checker configuration settings container.
+type Config struct {
+	// Addrs is the list of Redis node addresses used to build the underlying
+	// redis.UniversalClient: one address gives a single-node client, several
+	// give a cluster client. Sentinel (failover) clients are not built, as no
+	// master name is set.
+	Addrs []string
+	// Password is the Redis AUTH password, if any.
+	Password string
+	// DB selects the Redis logical database to use.
+	DB int
+	// TLSConfig enables TLS on the connection when set.
+	TLSConfig *tls.Config
+}
+
+// New builds a pooled redis.UniversalClient held for the life of the
+// checker and returns a check function that PINGs it, along with a Close
+// function to release the pool. Pass Close to health.Config.Close so it
+// runs when the Health instance is stopped.
+func New(config Config) (check func(ctx context.Context) error, closeClient func() error) {
+	client := redis.NewUniversalClient(&redis.UniversalOptions{
+		Addrs:     config.Addrs,
+		Password:  config.Password,
+		DB:        config.DB,
+		TLSConfig: config.TLSConfig,
+	})
+
+	check = func(ctx context.Context) error {
+		if err := client.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("could not ping redis: %w", err)
+		}
+		return nil
+	}
+
+	return check, client.Close
+}
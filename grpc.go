@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// grpcHealthServer adapts Health to the standard gRPC Health Checking
+// Protocol (grpc.health.v1.Health). Check reports either the aggregated
+// status of every registered check (when no service is given) or a single
+// registered check's status (matched by name against the "service" field).
+// Watch streams every subsequent transition to its subscriber.
+type grpcHealthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	h *Health
+
+	mu   sync.Mutex
+	wake map[chan struct{}]struct{}
+}
+
+// GRPCServer registers the standard grpc.health.v1.Health service against
+// srv, backed by the same checks registered on h. Transitions of Async
+// checks are streamed to Watch subscribers as they happen.
+func (h *Health) GRPCServer(srv *grpc.Server) {
+	g := &grpcHealthServer{
+		h:    h,
+		wake: make(map[chan struct{}]struct{}),
+	}
+
+	h.addStatusObserver(func(CheckState, CheckState) { g.broadcast() })
+
+	healthpb.RegisterHealthServer(srv, g)
+}
+
+func (g *grpcHealthServer) broadcast() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for ch := range g.wake {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Check implements grpc.health.v1.Health/Check.
+func (g *grpcHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, ok := g.h.statusFor(ctx, req.GetService())
+	if !ok {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch implements grpc.health.v1.Health/Watch, sending the current status
+// immediately and again every time it changes.
+func (g *grpcHealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	wake := make(chan struct{}, 1)
+
+	g.mu.Lock()
+	g.wake[wake] = struct{}{}
+	g.mu.Unlock()
+	defer func() {
+		g.mu.Lock()
+		delete(g.wake, wake)
+		g.mu.Unlock()
+	}()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	send := func() error {
+		st, ok := g.h.statusFor(stream.Context(), req.GetService())
+		if !ok {
+			st = healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+		if st == last {
+			return nil
+		}
+		last = st
+		return stream.Send(&healthpb.HealthCheckResponse{Status: st})
+	}
+
+	if err := send(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-wake:
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// statusFor maps service (the registered check Name, or "" for the
+// aggregated result across every registered check) to a serving status. The
+// second return value is false when service names a check that was never
+// registered.
+func (h *Health) statusFor(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	if service == "" {
+		result := h.measure(ctx, func(Kind) bool { return true })
+		if result.Status == statusUnavailable {
+			return healthpb.HealthCheckResponse_NOT_SERVING, true
+		}
+		return healthpb.HealthCheckResponse_SERVING, true
+	}
+
+	h.mu.Lock()
+	c, ok := h.checks[service]
+	h.mu.Unlock()
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+
+	if outcome := h.resultFor(ctx, c); outcome.err != nil {
+		return healthpb.HealthCheckResponse_NOT_SERVING, true
+	}
+	return healthpb.HealthCheckResponse_SERVING, true
+}
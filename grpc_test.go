@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestStatusFor_UnknownServiceIsUnknown(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	st, ok := h.statusFor(context.Background(), "never-registered")
+	assert.False(t, ok)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, st)
+}
+
+func TestStatusFor_RegisteredServiceMatchesItsOwnResult(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{
+		Name:  "failing",
+		Check: func(context.Context) error { return errors.New("boom") },
+	}))
+	require.NoError(t, h.Register(Config{
+		Name:  "passing",
+		Check: func(context.Context) error { return nil },
+	}))
+
+	st, ok := h.statusFor(context.Background(), "failing")
+	assert.True(t, ok)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, st)
+
+	st, ok = h.statusFor(context.Background(), "passing")
+	assert.True(t, ok)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, st)
+}
+
+func TestStatusFor_EmptyServiceReflectsAggregateStatus(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{
+		Name:  "failing",
+		Check: func(context.Context) error { return errors.New("boom") },
+	}))
+
+	st, ok := h.statusFor(context.Background(), "")
+	assert.True(t, ok)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, st)
+}
+
+func TestGRPCHealthServer_Check(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{
+		Name:  "passing",
+		Check: func(context.Context) error { return nil },
+	}))
+
+	g := &grpcHealthServer{h: h, wake: make(map[chan struct{}]struct{})}
+
+	resp, err := g.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "passing"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+
+	_, err = g.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "missing"})
+	assert.Error(t, err)
+}
+
+func TestGRPCHealthServer_BroadcastWakesWatchers(t *testing.T) {
+	g := &grpcHealthServer{wake: make(map[chan struct{}]struct{})}
+
+	wake := make(chan struct{}, 1)
+	g.mu.Lock()
+	g.wake[wake] = struct{}{}
+	g.mu.Unlock()
+
+	g.broadcast()
+
+	select {
+	case <-wake:
+	default:
+		t.Fatal("broadcast did not wake the registered watcher")
+	}
+}
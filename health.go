@@ -0,0 +1,381 @@
+// Package health provides a small health-check aggregator used to back
+// Kubernetes liveness/readiness probes and ad-hoc /status endpoints.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Kind controls which probe handler(s) a Config is evaluated by.
+type Kind int
+
+const (
+	// Readiness marks a check that only runs as part of the readiness probe.
+	// It is the zero value, so existing Config literals keep behaving the
+	// way they did before Kind was introduced.
+	Readiness Kind = iota
+	// Liveness marks a check that only runs as part of the liveness probe.
+	Liveness
+	// Both marks a check that runs as part of both probes.
+	Both
+)
+
+const (
+	statusOK                 = "OK"
+	statusPartiallyAvailable = "Partially Available"
+	statusUnavailable        = "Unavailable"
+)
+
+const defaultCheckTimeout = time.Second * 2
+
+// CheckFunc is the function signature expected by Config.Check.
+type CheckFunc func(ctx context.Context) error
+
+// Config carries the parameters used to register a check.
+type Config struct {
+	// Name is the name of the check, it must be unique among registered checks.
+	Name string
+	// Timeout is the timeout applied to this check. Defaults to 2s.
+	Timeout time.Duration
+	// SkipOnErr, if true, keeps the overall status at "Partially Available"
+	// instead of "Unavailable" when this check fails.
+	SkipOnErr bool
+	// Kind controls which probe handler(s) run this check. Defaults to Readiness,
+	// so dependency checks (Postgres, Mongo, MySQL, RabbitMQ, HTTP, ...) keep
+	// being reported on /status and /ready without being repeated on /live.
+	Kind Kind
+	// Async, if true, runs this check in a background goroutine every
+	// Interval instead of inline on every probe request. The probe handlers
+	// then serve the latest cached result rather than blocking on the check.
+	Async bool
+	// Interval is the cadence background checks run on. Only used when
+	// Async is true; defaults to 10 * Timeout.
+	Interval time.Duration
+	// Check is the function executed to determine health.
+	Check CheckFunc
+	// Close, if set, releases any resources (e.g. a pooled client) backing
+	// Check. Stop invokes it once, after the background check goroutines
+	// have returned.
+	Close func() error
+}
+
+// Component identifies the service reporting its health. It is included as
+// a top-level field of AggregatedResult when WithComponent is used.
+type Component struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// CheckResult is the per-check entry of an AggregatedResult.
+type CheckResult struct {
+	Status     string    `json:"status"`
+	Timestamp  time.Time `json:"timestamp"`
+	DurationMs int64     `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AggregatedResult is the JSON body served by the probe handlers.
+type AggregatedResult struct {
+	Status    string                 `json:"status"`
+	Component *Component             `json:"component,omitempty"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Health aggregates registered checks and serves them over HTTP.
+type Health struct {
+	mu     sync.Mutex
+	checks map[string]Config
+
+	registerer prometheus.Registerer
+	metrics    *checkMetrics
+
+	cacheDuration time.Duration
+	cacheMu       sync.RWMutex
+	cache         map[string]cachedResult
+
+	component *Component
+
+	obsMu     sync.Mutex
+	lastState map[string]CheckState
+	observers []func(oldState, newState CheckState)
+	history   sync.Map
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a Health instance with the default liveness checks
+// (goroutine count and GC pause thresholds) already registered.
+func New(opts ...Option) (*Health, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &Health{
+		checks:    make(map[string]Config),
+		cache:     make(map[string]cachedResult),
+		lastState: make(map[string]CheckState),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if err := h.Register(Config{
+		Name:  "goroutine-threshold",
+		Kind:  Liveness,
+		Check: checkGoroutines(defaultMaxGoroutines),
+	}); err != nil {
+		return nil, err
+	}
+	if err := h.Register(Config{
+		Name:  "gc-pause-threshold",
+		Kind:  Liveness,
+		Check: checkGCPause(defaultMaxGCPauseMs),
+	}); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// Register adds a check to the aggregator. Names must be unique.
+func (h *Health) Register(c Config) error {
+	if c.Name == "" {
+		return errors.New("health check must have a name to be registered")
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultCheckTimeout
+	}
+
+	h.mu.Lock()
+	if _, ok := h.checks[c.Name]; ok {
+		h.mu.Unlock()
+		return fmt.Errorf("health check %q is already registered", c.Name)
+	}
+	h.checks[c.Name] = c
+	h.mu.Unlock()
+
+	// Run the warm-up (and start the ticker) without holding h.mu: Check can
+	// block for up to c.Timeout against a real dependency, and measure()
+	// needs h.mu just to snapshot the checks map for every probe request.
+	if c.Async {
+		h.runAndCache(c)
+		h.startAsync(c)
+	}
+
+	return nil
+}
+
+// Stop cancels every background check goroutine started for Async checks,
+// waits for them to return, then runs every registered check's Close hook to
+// release pooled resources such as a Redis client. If ctx is done before the
+// goroutines return, Stop returns ctx.Err() without running any Close hook.
+func (h *Health) Stop(ctx context.Context) error {
+	h.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	h.mu.Lock()
+	checks := make([]Config, 0, len(h.checks))
+	for _, c := range h.checks {
+		checks = append(checks, c)
+	}
+	h.mu.Unlock()
+
+	var msgs []string
+	for _, c := range checks {
+		if c.Close == nil {
+			continue
+		}
+		if err := c.Close(); err != nil {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", c.Name, err))
+		}
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("health: error closing checks: %s", strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
+// Handler returns an http.Handler that runs every registered check,
+// regardless of Kind. It backs the historical /status endpoint.
+func (h *Health) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serveProbe(w, r, func(Kind) bool { return true })
+	})
+}
+
+// ReadinessHandler returns an http.Handler that only runs checks registered
+// with Kind Readiness or Both. Failures here should pull the pod out of the
+// load balancer without restarting it.
+func (h *Health) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serveProbe(w, r, func(k Kind) bool { return k == Readiness || k == Both })
+	})
+}
+
+// LivenessHandler returns an http.Handler that only runs checks registered
+// with Kind Liveness or Both. Failures here should restart the pod, so this
+// is meant to stay cheap (Go-runtime checks by default) and independent of
+// downstream dependencies.
+func (h *Health) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.serveProbe(w, r, func(k Kind) bool { return k == Liveness || k == Both })
+	})
+}
+
+// MetricsHandler exposes the gauges and histograms produced by
+// WithPrometheusRegisterer for scraping, e.g. mounted at /metrics.
+func (h *Health) MetricsHandler() http.Handler {
+	gatherer := prometheus.DefaultGatherer
+	if g, ok := h.registerer.(prometheus.Gatherer); ok {
+		gatherer = g
+	}
+
+	return promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+}
+
+func (h *Health) serveProbe(w http.ResponseWriter, r *http.Request, include func(Kind) bool) {
+	result := h.measure(r.Context(), include)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	code := http.StatusOK
+	if result.Status == statusUnavailable {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	w.Write(data)
+}
+
+func (h *Health) measure(ctx context.Context, include func(Kind) bool) AggregatedResult {
+	h.mu.Lock()
+	checks := make([]Config, 0, len(h.checks))
+	for _, c := range h.checks {
+		if include(c.Kind) {
+			checks = append(checks, c)
+		}
+	}
+	h.mu.Unlock()
+
+	status := statusOK
+	results := make(map[string]CheckResult, len(checks))
+
+	for _, c := range checks {
+		outcome := h.resultFor(ctx, c)
+
+		result := CheckResult{
+			Status:     statusOK,
+			Timestamp:  outcome.at,
+			DurationMs: outcome.duration.Milliseconds(),
+		}
+
+		if outcome.err != nil {
+			result.Error = outcome.err.Error()
+			if c.SkipOnErr {
+				result.Status = statusPartiallyAvailable
+				status = upgradeStatus(status, statusPartiallyAvailable)
+			} else {
+				result.Status = statusUnavailable
+				status = statusUnavailable
+			}
+		}
+
+		results[c.Name] = result
+	}
+
+	return AggregatedResult{Status: status, Component: h.component, Checks: results}
+}
+
+// checkOutcome is the result of a single check run, cached or fresh, along
+// with when it ran and how long it took.
+type checkOutcome struct {
+	err      error
+	at       time.Time
+	duration time.Duration
+}
+
+// resultFor returns the outcome of c, either by reusing a cached result
+// (Async checks always serve the latest background result; sync checks
+// reuse a result still within the configured cache duration) or by running
+// it inline.
+func (h *Health) resultFor(ctx context.Context, c Config) checkOutcome {
+	if c.Async {
+		h.cacheMu.RLock()
+		cached, ok := h.cache[c.Name]
+		h.cacheMu.RUnlock()
+		if ok {
+			return checkOutcome{err: cached.err, at: cached.at, duration: cached.duration}
+		}
+		return checkOutcome{at: time.Now()}
+	}
+
+	if h.cacheDuration > 0 {
+		h.cacheMu.RLock()
+		cached, ok := h.cache[c.Name]
+		h.cacheMu.RUnlock()
+		if ok && time.Since(cached.at) < h.cacheDuration {
+			return checkOutcome{err: cached.err, at: cached.at, duration: cached.duration}
+		}
+	}
+
+	err, elapsed := h.runCheck(ctx, c)
+	now := time.Now()
+	h.notifyTransition(c.Name, err)
+
+	if h.cacheDuration > 0 {
+		h.cacheMu.Lock()
+		h.cache[c.Name] = cachedResult{at: now, err: err, duration: elapsed}
+		h.cacheMu.Unlock()
+	}
+
+	return checkOutcome{err: err, at: now, duration: elapsed}
+}
+
+func (h *Health) runCheck(ctx context.Context, c Config) (error, time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Check(ctx)
+	elapsed := time.Since(start)
+	h.metrics.observe(c.Name, err, elapsed)
+
+	return err, elapsed
+}
+
+// upgradeStatus never downgrades an already-Unavailable status.
+func upgradeStatus(current, candidate string) string {
+	if current == statusUnavailable {
+		return current
+	}
+	return candidate
+}
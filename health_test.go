@@ -0,0 +1,190 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegister_DuplicateName(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{Name: "dup", Check: func(context.Context) error { return nil }}))
+
+	err = h.Register(Config{Name: "dup", Check: func(context.Context) error { return nil }})
+	assert.EqualError(t, err, `health check "dup" is already registered`)
+}
+
+func TestRegister_RequiresName(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	err = h.Register(Config{Check: func(context.Context) error { return nil }})
+	assert.EqualError(t, err, "health check must have a name to be registered")
+}
+
+func TestMeasure_SkipOnErr(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{
+		Name:      "flaky",
+		SkipOnErr: true,
+		Check:     func(context.Context) error { return errors.New("boom") },
+	}))
+
+	result := h.measure(context.Background(), func(Kind) bool { return true })
+	assert.Equal(t, statusPartiallyAvailable, result.Status)
+	assert.Equal(t, statusPartiallyAvailable, result.Checks["flaky"].Status)
+	assert.Equal(t, "boom", result.Checks["flaky"].Error)
+}
+
+func TestMeasure_FailureWithoutSkipIsUnavailable(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{
+		Name:  "down",
+		Check: func(context.Context) error { return errors.New("boom") },
+	}))
+
+	result := h.measure(context.Background(), func(Kind) bool { return true })
+	assert.Equal(t, statusUnavailable, result.Status)
+}
+
+// TestRegister_AsyncWarmUpDoesNotBlockConcurrentMeasure guards against the
+// warm-up run regaining h.mu: a blocked Check must never stall a concurrent
+// probe that only needs to snapshot the checks map.
+func TestRegister_AsyncWarmUpDoesNotBlockConcurrentMeasure(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	release := make(chan struct{})
+	registered := make(chan struct{})
+
+	go func() {
+		h.Register(Config{
+			Name:     "slow",
+			Async:    true,
+			Interval: time.Hour,
+			Check: func(context.Context) error {
+				close(registered)
+				<-release
+				return nil
+			},
+		})
+	}()
+
+	select {
+	case <-registered:
+	case <-time.After(time.Second):
+		t.Fatal("warm-up check never started")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.measure(context.Background(), func(Kind) bool { return true })
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("measure blocked on in-flight async warm-up, h.mu was held too long")
+	}
+
+	close(release)
+}
+
+func TestAsync_ServesCachedResultUntilTickerRuns(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	var calls int32
+	require.NoError(t, h.Register(Config{
+		Name:     "async-check",
+		Async:    true,
+		Interval: time.Hour,
+		Check: func(context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		},
+	}))
+
+	result := h.measure(context.Background(), func(Kind) bool { return true })
+	assert.Equal(t, statusOK, result.Checks["async-check"].Status)
+
+	result = h.measure(context.Background(), func(Kind) bool { return true })
+	assert.Equal(t, statusOK, result.Checks["async-check"].Status)
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "async check should only run on warm-up, not on every probe")
+}
+
+func TestWithCacheDuration_ReusesResultWithinTTL(t *testing.T) {
+	h, err := New(WithCacheDuration(time.Hour))
+	require.NoError(t, err)
+
+	var calls int32
+	require.NoError(t, h.Register(Config{
+		Name: "cached-check",
+		Check: func(context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		},
+	}))
+
+	h.measure(context.Background(), func(Kind) bool { return true })
+	h.measure(context.Background(), func(Kind) bool { return true })
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls), "second measure within the cache TTL should reuse the cached result")
+}
+
+func TestStop_RunsCloseHooksAfterAsyncGoroutinesDrain(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	var closed int32
+	require.NoError(t, h.Register(Config{
+		Name:     "closeable",
+		Async:    true,
+		Interval: time.Millisecond,
+		Check:    func(context.Context) error { return nil },
+		Close: func() error {
+			atomic.AddInt32(&closed, 1)
+			return nil
+		},
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, h.Stop(ctx))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&closed))
+}
+
+func TestStop_JoinsCloseErrors(t *testing.T) {
+	h, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, h.Register(Config{
+		Name:  "a",
+		Check: func(context.Context) error { return nil },
+		Close: func() error { return errors.New("a failed") },
+	}))
+	require.NoError(t, h.Register(Config{
+		Name:  "b",
+		Check: func(context.Context) error { return nil },
+		Close: func() error { return errors.New("b failed") },
+	}))
+
+	err = h.Stop(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a: a failed")
+	assert.Contains(t, err.Error(), "b: b failed")
+}
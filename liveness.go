@@ -0,0 +1,42 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+const (
+	defaultMaxGoroutines = 2000
+	defaultMaxGCPauseMs  = 500
+)
+
+// checkGoroutines fails the liveness probe once the number of live
+// goroutines suggests the process is leaking or wedged.
+func checkGoroutines(max int) CheckFunc {
+	return func(context.Context) error {
+		if n := runtime.NumGoroutine(); n > max {
+			return fmt.Errorf("goroutine count %d exceeds threshold %d", n, max)
+		}
+		return nil
+	}
+}
+
+// checkGCPause fails the liveness probe once the most recent GC pause
+// suggests the process is under severe memory pressure.
+func checkGCPause(maxMs uint64) CheckFunc {
+	return func(context.Context) error {
+		var stats debug.GCStats
+		debug.ReadGCStats(&stats)
+		if len(stats.Pause) == 0 {
+			return nil
+		}
+
+		pauseMs := uint64(stats.Pause[0].Milliseconds())
+		if pauseMs > maxMs {
+			return fmt.Errorf("last GC pause %dms exceeds threshold %dms", pauseMs, maxMs)
+		}
+		return nil
+	}
+}
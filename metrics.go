@@ -0,0 +1,47 @@
+package health
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// checkMetrics holds the Prometheus collectors instrumenting every check
+// run when WithPrometheusRegisterer is used.
+type checkMetrics struct {
+	status   *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+}
+
+func newCheckMetrics(reg prometheus.Registerer) *checkMetrics {
+	m := &checkMetrics{
+		status: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "healthcheck_status",
+			Help: "Result of the last run of a health check, 1 for healthy and 0 for unhealthy.",
+		}, []string{"check"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "healthcheck_check_duration_seconds",
+			Help: "Duration in seconds of each health check run.",
+		}, []string{"check"}),
+	}
+
+	reg.MustRegister(m.status, m.duration)
+
+	return m
+}
+
+// observe records the outcome of a single check run. It is a no-op when
+// metrics haven't been enabled via WithPrometheusRegisterer.
+func (m *checkMetrics) observe(name string, err error, elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+
+	status := 1.0
+	if err != nil {
+		status = 0
+	}
+
+	m.status.WithLabelValues(name).Set(status)
+	m.duration.WithLabelValues(name).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Option configures optional behaviour on a Health instance.
+type Option func(*Health)
+
+// WithPrometheusRegisterer instruments every Register call so that each
+// check reports a "healthcheck_status" gauge (1 for healthy, 0 for
+// unhealthy, including SkipOnErr checks) and a
+// "healthcheck_check_duration_seconds" histogram to reg. Pair it with
+// MetricsHandler to expose the result on a /metrics endpoint instead of
+// scraping /status from every pod.
+func WithPrometheusRegisterer(reg prometheus.Registerer) Option {
+	return func(h *Health) {
+		h.registerer = reg
+		h.metrics = newCheckMetrics(reg)
+	}
+}
+
+// WithCacheDuration sets how long a synchronous check's result is reused
+// before being run again, so rapid consecutive probes (e.g. a probe storm)
+// reuse the last result instead of hammering the dependency. It has no
+// effect on Async checks, which are always served from the background
+// goroutine's latest result.
+func WithCacheDuration(d time.Duration) Option {
+	return func(h *Health) {
+		h.cacheDuration = d
+	}
+}
+
+// WithComponent attaches a name and version to every AggregatedResult,
+// matching the hellofresh health-go v5 response shape.
+func WithComponent(name, version string) Option {
+	return func(h *Health) {
+		h.component = &Component{Name: name, Version: version}
+	}
+}
+
+// StatusListener is notified whenever a check transitions between healthy
+// and unhealthy.
+type StatusListener func(ctx context.Context, oldState, newState CheckState)
+
+// WithStatusListener registers fn to run whenever any check transitions
+// between healthy and unhealthy, e.g. to emit structured logs or push events
+// to an incident system without scraping /status. fn always runs on its own
+// goroutine so a slow hook can never block a probe request.
+func WithStatusListener(fn StatusListener) Option {
+	return func(h *Health) {
+		h.addStatusObserver(func(oldState, newState CheckState) {
+			go fn(context.Background(), oldState, newState)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// maxHistoryEntries bounds how many past transitions History keeps per check.
+const maxHistoryEntries = 20
+
+// CheckState is a snapshot of a single check's outcome at a point in time.
+type CheckState struct {
+	Name      string
+	Healthy   bool
+	Err       error
+	Timestamp time.Time
+}
+
+type history struct {
+	mu     sync.Mutex
+	states []CheckState
+}
+
+// addStatusObserver registers fn to be called whenever a check transitions
+// between healthy and unhealthy. Used internally to drive the gRPC Watch
+// stream; WithStatusListener (see option.go) hooks into the same mechanism.
+func (h *Health) addStatusObserver(fn func(oldState, newState CheckState)) {
+	h.obsMu.Lock()
+	h.observers = append(h.observers, fn)
+	h.obsMu.Unlock()
+}
+
+// notifyTransition records the latest outcome for name and, if it is the
+// first outcome seen for name or differs from the previous outcome, appends
+// it to that check's History and fires every registered observer.
+func (h *Health) notifyTransition(name string, err error) {
+	newState := CheckState{Name: name, Healthy: err == nil, Err: err, Timestamp: time.Now()}
+
+	h.obsMu.Lock()
+	oldState, known := h.lastState[name]
+	h.lastState[name] = newState
+	observers := h.observers
+	h.obsMu.Unlock()
+
+	if known && oldState.Healthy == newState.Healthy {
+		return
+	}
+
+	h.recordHistory(newState)
+
+	for _, obs := range observers {
+		obs(oldState, newState)
+	}
+}
+
+func (h *Health) recordHistory(state CheckState) {
+	v, _ := h.history.LoadOrStore(state.Name, &history{})
+	entry := v.(*history)
+
+	entry.mu.Lock()
+	entry.states = append(entry.states, state)
+	if len(entry.states) > maxHistoryEntries {
+		entry.states = entry.states[len(entry.states)-maxHistoryEntries:]
+	}
+	entry.mu.Unlock()
+}
+
+// History returns the most recent transitions recorded for the named check,
+// oldest first. It returns nil if the check has never transitioned.
+func (h *Health) History(name string) []CheckState {
+	v, ok := h.history.Load(name)
+	if !ok {
+		return nil
+	}
+	entry := v.(*history)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	states := make([]CheckState, len(entry.states))
+	copy(states, entry.states)
+	return states
+}
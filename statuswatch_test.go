@@ -0,0 +1,91 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHealth() *Health {
+	return &Health{
+		checks:    make(map[string]Config),
+		cache:     make(map[string]cachedResult),
+		lastState: make(map[string]CheckState),
+	}
+}
+
+func TestNotifyTransition_OnlyFiresOnActualTransition(t *testing.T) {
+	h := newTestHealth()
+
+	var transitions []CheckState
+	h.addStatusObserver(func(oldState, newState CheckState) {
+		transitions = append(transitions, newState)
+	})
+
+	h.notifyTransition("svc", nil)
+	h.notifyTransition("svc", nil)
+
+	assert.Len(t, transitions, 1, "repeated healthy results must not fire another transition")
+
+	h.notifyTransition("svc", assertErr)
+	assert.Len(t, transitions, 2)
+	assert.False(t, transitions[1].Healthy)
+}
+
+func TestNotifyTransition_FirstResultAlwaysTransitions(t *testing.T) {
+	h := newTestHealth()
+
+	var calls int
+	h.addStatusObserver(func(oldState, newState CheckState) {
+		calls++
+	})
+
+	h.notifyTransition("svc", assertErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestHistory_RecordsOnlyTransitionsOldestFirst(t *testing.T) {
+	h := newTestHealth()
+
+	h.notifyTransition("svc", nil)
+	h.notifyTransition("svc", nil)
+	h.notifyTransition("svc", assertErr)
+	h.notifyTransition("svc", nil)
+
+	states := h.History("svc")
+	if assert.Len(t, states, 3) {
+		assert.True(t, states[0].Healthy)
+		assert.False(t, states[1].Healthy)
+		assert.True(t, states[2].Healthy)
+	}
+}
+
+func TestHistory_UnknownCheckReturnsNil(t *testing.T) {
+	h := newTestHealth()
+	assert.Nil(t, h.History("never-registered"))
+}
+
+func TestHistory_BoundedAtMaxEntries(t *testing.T) {
+	h := newTestHealth()
+
+	healthy := true
+	for i := 0; i < maxHistoryEntries+5; i++ {
+		healthy = !healthy
+		if healthy {
+			h.notifyTransition("svc", nil)
+		} else {
+			h.notifyTransition("svc", assertErr)
+		}
+	}
+
+	states := h.History("svc")
+	assert.Len(t, states, maxHistoryEntries)
+}
+
+// assertErr is a sentinel error used across these tests; its message is
+// irrelevant, only its nilness is observed by notifyTransition.
+var assertErr = &transitionTestError{}
+
+type transitionTestError struct{}
+
+func (*transitionTestError) Error() string { return "check failed" }